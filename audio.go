@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"io"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+)
+
+// Sample rate used for the shared audio context.
+const sampleRate = 44100
+
+//go:embed assets/sfx/jump.wav
+var jumpSoundData []byte
+
+//go:embed assets/sfx/score.wav
+var scoreSoundData []byte
+
+//go:embed assets/sfx/hit.wav
+var hitSoundData []byte
+
+// soundEffects groups the players for every in-game sound effect.
+type soundEffects struct {
+	jump  *audio.Player
+	score *audio.Player
+	hit   *audio.Player
+}
+
+// newSoundEffects decodes the embedded sound assets and creates a player for each.
+func newSoundEffects(ctx *audio.Context) *soundEffects {
+	return &soundEffects{
+		jump:  newPlayer(ctx, jumpSoundData),
+		score: newPlayer(ctx, scoreSoundData),
+		hit:   newPlayer(ctx, hitSoundData),
+	}
+}
+
+// newPlayer decodes WAV data at the context's sample rate and returns a ready-to-play player.
+func newPlayer(ctx *audio.Context, data []byte) *audio.Player {
+	stream, err := wav.DecodeWithSampleRate(sampleRate, bytes.NewReader(data))
+	if err != nil {
+		log.Fatal(err)
+	}
+	raw, err := io.ReadAll(stream)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return ctx.NewPlayerFromBytes(raw)
+}
+
+// play rewinds and plays p from the start, so rapid retriggers don't get dropped.
+func play(p *audio.Player) {
+	if p == nil {
+		return
+	}
+	if err := p.Rewind(); err != nil {
+		log.Print(err)
+		return
+	}
+	p.Play()
+}
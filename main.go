@@ -1,14 +1,15 @@
 package main
 
 import (
+	"flag"
 	"image/color"
 	"log"
 	"math/rand"
-	"strconv"
+	"os"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/audio"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
@@ -27,20 +28,25 @@ const (
 	startMessageY    = screenHeight/2 - 30
 	gameOverMessageX = screenWidth/2 - 100
 	gameOverMessageY = screenHeight / 2
+	bestMessageY     = gameOverMessageY + 20
+	pauseMessageX    = screenWidth/2 - 60
+	pauseMessageY    = screenHeight / 2
 )
 
 // Text messages
 var (
 	textWindowTitle  = "Go Flappy Bird Clone"
 	textStartMessage = "Press SPACE to start and for jump"
+	textPaused       = "Paused - Press P to resume"
 	textGameOver     = "Game Over! Press R to restart"
+	textNewBest      = "New Best!"
 	textScorePrefix  = "Score: "
+	textBestPrefix   = "Best: "
 )
 
 // Colors
 var (
 	colorBackground = color.RGBA{120, 200, 240, 255} // Blue (sky)
-	colorBird       = color.RGBA{255, 255, 0, 255}   // Yellow
 	colorPipe       = color.RGBA{40, 140, 40, 255}   // Green
 )
 
@@ -53,24 +59,43 @@ type Pipe struct {
 
 // Game structure
 type Game struct {
+	mode          Mode
 	birdY         float64
 	birdVelocity  float64
 	pipes         []Pipe
 	score         int
-	gameOver      bool
-	started       bool
+	record        ScoreRecord
+	newBest       bool
 	nextPipeSpawn float64
+	sfx           *soundEffects
+	muted         bool
+	animTick      int
+	groundScrollX float64
+	cityScrollX   float64
+	crtEnabled    bool
+	crtScene      *ebiten.Image
+	crtTime       float64
+
+	physicsAccum float64
+	prevBirdY    float64
+
+	replayRecorder *replayRecorder
+	replayPlayer   *replayPlayer
 }
 
-// Resets the game state
+// Resets the game state and returns to the title screen
 func (g *Game) reset() {
+	g.mode = ModeTitle
 	g.birdY = screenHeight / 2
 	g.birdVelocity = 0
 	g.pipes = nil
 	g.score = 0
-	g.gameOver = false
-	g.started = false
+	g.newBest = false
 	g.nextPipeSpawn = screenWidth
+	g.animTick = 0
+	g.groundScrollX = 0
+	g.cityScrollX = 0
+	g.resetPhysicsClock()
 }
 
 // Initialize a random number generator
@@ -82,75 +107,22 @@ func (g *Game) spawnPipe() {
 	g.pipes = append(g.pipes, Pipe{x: screenWidth, height: topHeight, passed: false})
 }
 
-// Game update logic
-func (g *Game) Update() error {
-	// Game starts with the first space press
-	if !g.started {
-		if ebiten.IsKeyPressed(ebiten.KeySpace) {
-			g.started = true
-		}
-		return nil
-	}
-
-	// Handle game over reset
-	if g.gameOver {
-		if ebiten.IsKeyPressed(ebiten.KeyR) {
-			g.reset()
-		}
-		return nil
-	}
-
-	// Handle bird jump
-	if ebiten.IsKeyPressed(ebiten.KeySpace) {
-		g.birdVelocity = jumpStrength
-	}
-
-	// Update bird position
-	g.birdVelocity += gravity
-	g.birdY += g.birdVelocity
-
-	// Check if the bird hits the ground
-	if g.birdY > screenHeight-birdSize {
-		g.birdY = screenHeight - birdSize
-		g.gameOver = true
-	}
-
-	// Check if the bird hits the top
-	if g.birdY < 0 {
-		g.birdY = 0
-		g.gameOver = true
-	}
-
-	// Spawn new pipes at intervals
-	if g.nextPipeSpawn <= 0 {
-		g.spawnPipe()
-		g.nextPipeSpawn = pipeSpacing
-	}
-	g.nextPipeSpawn -= pipeSpeed
-
-	// Update pipes and check for collisions
-	for i := range g.pipes {
-		g.pipes[i].x -= pipeSpeed
-
-		// Check if the bird passes through the pipes for scoring
-		if !g.pipes[i].passed && g.pipes[i].x+pipeWidth < screenWidth/2 {
-			g.score++
-			g.pipes[i].passed = true
-		}
-
-		// Collision detection with pipes
-		if (screenWidth/2 >= g.pipes[i].x && screenWidth/2 <= g.pipes[i].x+pipeWidth) &&
-			(g.birdY <= g.pipes[i].height || g.birdY+birdSize >= g.pipes[i].height+pipeGap) {
-			g.gameOver = true
-		}
+// die ends the current run, persists the score record, and plays the hit sound effect.
+func (g *Game) die() {
+	if g.mode == ModeGameOver {
+		return
 	}
+	g.mode = ModeGameOver
+	g.recordRun()
+	g.playSound(g.sfx.hit)
+}
 
-	// Remove pipes that have moved off screen
-	if len(g.pipes) > 0 && g.pipes[0].x+pipeWidth < 0 {
-		g.pipes = g.pipes[1:]
+// playSound plays p unless the player has muted sound effects.
+func (g *Game) playSound(p *audio.Player) {
+	if g.muted {
+		return
 	}
-
-	return nil
+	play(p)
 }
 
 // Draw a filled rectangle with the specified color
@@ -158,13 +130,13 @@ func drawFilledRect(screen *ebiten.Image, x, y, width, height float32, clr color
 	vector.DrawFilledRect(screen, x, y, width, height, clr, false) // false means no anti-aliasing
 }
 
-// Drawing logic for the game
-func (g *Game) Draw(screen *ebiten.Image) {
+// drawScene renders the background, pipes, and bird shared by every mode.
+func (g *Game) drawScene(screen *ebiten.Image) {
 	// Clear the screen with background color
 	screen.Fill(colorBackground)
 
-	// Draw the bird
-	drawFilledRect(screen, float32(screenWidth/2-birdSize/2), float32(g.birdY), birdSize, birdSize, colorBird)
+	// Draw the farther-back cloud/city layer, then the pipes, then the nearer ground strip
+	drawParallaxLayer(screen, citySprite, -g.cityScrollX, screenHeight-groundHeight-float64(citySprite.Bounds().Dy()))
 
 	// Draw pipes
 	for _, pipe := range g.pipes {
@@ -174,18 +146,15 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		drawFilledRect(screen, float32(pipe.x), float32(pipe.height+pipeGap), pipeWidth, float32(screenHeight-pipe.height-pipeGap), colorPipe)
 	}
 
-	// Display score in the top left corner
-	ebitenutil.DebugPrintAt(screen, textScorePrefix+strconv.Itoa(g.score), 10, 10)
-
-	// Display start message if game hasn't started
-	if !g.started {
-		ebitenutil.DebugPrintAt(screen, textStartMessage, startMessageX, startMessageY)
-	}
+	drawParallaxLayer(screen, groundSprite, -g.groundScrollX, screenHeight-groundHeight)
 
-	// Display Game Over message if game is over
-	if g.gameOver {
-		ebitenutil.DebugPrintAt(screen, textGameOver, gameOverMessageX, gameOverMessageY)
-	}
+	// Draw the animated, rotated bird at its interpolated render position
+	frame := birdFrame((g.animTick / birdAnimationTicks) % birdFrameCount)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(-birdFrameSize/2, -birdFrameSize/2)
+	op.GeoM.Rotate(birdRotation(g.birdVelocity))
+	op.GeoM.Translate(screenWidth/2, g.renderBirdY()+birdFrameSize/2)
+	screen.DrawImage(frame, op)
 }
 
 // Defines the layout of the game window
@@ -194,7 +163,41 @@ func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
 }
 
 func main() {
-	game := &Game{}
+	crt := flag.Bool("crt", false, "enable the CRT post-processing shader on startup")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "seed for the pipe RNG, for reproducible pipe layouts")
+	replayPath := flag.String("replay", "", "record space-press timings to this file, or play them back if it already exists")
+	flag.Parse()
+
+	rng = rand.New(rand.NewSource(*seed))
+
+	var recorder *replayRecorder
+	var player *replayPlayer
+	if *replayPath != "" {
+		if _, err := os.Stat(*replayPath); err == nil {
+			p, err := loadReplayPlayer(*replayPath)
+			if err != nil {
+				log.Fatal(err)
+			}
+			player = p
+		} else {
+			r, err := newReplayRecorder(*replayPath)
+			if err != nil {
+				log.Fatal(err)
+			}
+			recorder = r
+			defer r.Close()
+		}
+	}
+
+	audioContext := audio.NewContext(sampleRate)
+
+	game := &Game{
+		sfx:            newSoundEffects(audioContext),
+		record:         loadScoreData(),
+		crtEnabled:     *crt,
+		replayRecorder: recorder,
+		replayPlayer:   player,
+	}
 	game.reset()
 
 	ebiten.SetWindowSize(screenWidth, screenHeight)
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Mode identifies which scene the game is currently in.
+type Mode int
+
+const (
+	ModeTitle Mode = iota
+	ModePlay
+	ModePause
+	ModeGameOver
+)
+
+// Update dispatches to the handler for the current mode.
+func (g *Game) Update() error {
+	// Toggle mute and the CRT shader at any time, in any mode
+	if inpututil.IsKeyJustPressed(ebiten.KeyM) {
+		g.muted = !g.muted
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF1) {
+		g.crtEnabled = !g.crtEnabled
+	}
+	g.crtTime += 1.0 / 60.0
+
+	switch g.mode {
+	case ModeTitle:
+		return g.updateTitle()
+	case ModePlay:
+		return g.updatePlay()
+	case ModePause:
+		return g.updatePause()
+	case ModeGameOver:
+		return g.updateGameOver()
+	}
+	return nil
+}
+
+// updateTitle waits for the player to start the game.
+func (g *Game) updateTitle() error {
+	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		g.mode = ModePlay
+		g.resetPhysicsClock()
+	}
+	return nil
+}
+
+// spacePressed reports whether space should trigger a jump this tick, sourced
+// from the replay file when one is being played back, from real input
+// otherwise, and recorded to a replay file when one is being written.
+func (g *Game) spacePressed() bool {
+	if g.replayPlayer != nil {
+		return g.replayPlayer.spacePressed()
+	}
+	pressed := inpututil.IsKeyJustPressed(ebiten.KeySpace)
+	if pressed && g.replayRecorder != nil {
+		g.replayRecorder.recordSpace()
+	}
+	return pressed
+}
+
+// updatePlay runs the bird physics, pipe spawning, and collision checks.
+func (g *Game) updatePlay() error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyP) {
+		g.mode = ModePause
+		return nil
+	}
+
+	if g.replayRecorder != nil {
+		g.replayRecorder.tickDone()
+	}
+	if g.replayPlayer != nil {
+		g.replayPlayer.tickDone()
+	}
+
+	// Handle bird jump
+	if g.spacePressed() {
+		g.jump()
+		g.playSound(g.sfx.jump)
+	}
+
+	// Step the bird's physics at a fixed rate, independent of the tick rate
+	g.advancePhysics()
+
+	// Check if the bird hits the ground (the visual ground strip's surface,
+	// not the bottom of the screen, since ground.png is opaque from its
+	// topmost row)
+	if g.birdY > screenHeight-groundHeight-birdSize {
+		g.birdY = screenHeight - groundHeight - birdSize
+		g.die()
+	}
+
+	// Check if the bird hits the top
+	if g.birdY < 0 {
+		g.birdY = 0
+		g.die()
+	}
+
+	// Advance the wing-flap animation and parallax scroll offsets
+	g.animTick++
+	g.groundScrollX += pipeSpeed * groundScrollFraction
+	g.cityScrollX += pipeSpeed * cityScrollFraction
+
+	// Spawn new pipes at intervals
+	if g.nextPipeSpawn <= 0 {
+		g.spawnPipe()
+		g.nextPipeSpawn = pipeSpacing
+	}
+	g.nextPipeSpawn -= pipeSpeed
+
+	// Update pipes and check for collisions
+	for i := range g.pipes {
+		g.pipes[i].x -= pipeSpeed
+
+		// Check if the bird passes through the pipes for scoring
+		if !g.pipes[i].passed && g.pipes[i].x+pipeWidth < screenWidth/2 {
+			g.score++
+			g.pipes[i].passed = true
+			g.playSound(g.sfx.score)
+		}
+
+		// Collision detection with pipes
+		if (screenWidth/2 >= g.pipes[i].x && screenWidth/2 <= g.pipes[i].x+pipeWidth) &&
+			(g.birdY <= g.pipes[i].height || g.birdY+birdSize >= g.pipes[i].height+pipeGap) {
+			g.die()
+		}
+	}
+
+	// Remove pipes that have moved off screen
+	if len(g.pipes) > 0 && g.pipes[0].x+pipeWidth < 0 {
+		g.pipes = g.pipes[1:]
+	}
+
+	return nil
+}
+
+// updatePause waits for the player to resume.
+func (g *Game) updatePause() error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyP) {
+		g.mode = ModePlay
+		g.resetPhysicsClock()
+	}
+	return nil
+}
+
+// updateGameOver waits for the player to start a new run.
+func (g *Game) updateGameOver() error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyR) {
+		g.reset()
+	}
+	return nil
+}
+
+// Draw dispatches to the renderer for the current mode. When the CRT shader
+// is enabled, the scene is first rendered to an offscreen image and then
+// composited onto screen through the shader.
+func (g *Game) Draw(screen *ebiten.Image) {
+	target := screen
+	if g.crtEnabled {
+		target = g.sceneImage()
+	}
+
+	g.drawScene(target)
+	ebitenutil.DebugPrintAt(target, textScorePrefix+strconv.Itoa(g.score), 10, 10)
+	ebitenutil.DebugPrintAt(target, textBestPrefix+strconv.Itoa(g.record.Best), 10, 25)
+
+	switch g.mode {
+	case ModeTitle:
+		g.drawTitle(target)
+	case ModePause:
+		g.drawPause(target)
+	case ModeGameOver:
+		g.drawGameOver(target)
+	}
+
+	if g.crtEnabled {
+		g.applyCRTShader(screen)
+	}
+}
+
+// drawTitle shows the start prompt.
+func (g *Game) drawTitle(screen *ebiten.Image) {
+	ebitenutil.DebugPrintAt(screen, textStartMessage, startMessageX, startMessageY)
+}
+
+// drawPause shows the pause banner over the frozen scene.
+func (g *Game) drawPause(screen *ebiten.Image) {
+	ebitenutil.DebugPrintAt(screen, textPaused, pauseMessageX, pauseMessageY)
+}
+
+// drawGameOver shows the final score alongside the best score so far, plus a
+// banner when this run set a new best.
+func (g *Game) drawGameOver(screen *ebiten.Image) {
+	ebitenutil.DebugPrintAt(screen, textGameOver, gameOverMessageX, gameOverMessageY)
+	ebitenutil.DebugPrintAt(screen, textBestPrefix+strconv.Itoa(g.record.Best), gameOverMessageX, bestMessageY)
+	if g.newBest {
+		ebitenutil.DebugPrintAt(screen, textNewBest, gameOverMessageX, bestMessageY+15)
+	}
+}
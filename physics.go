@@ -0,0 +1,61 @@
+package main
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// Fixed-timestep physics settings. Gravity and jump strength are tuned per a
+// 1/60s tick (see the Screen and game settings block in main.go); the values
+// below rescale them to continuous units/sec so substepping at physicsRate
+// reproduces the original feel regardless of the render/tick rate.
+const (
+	physicsRate = 120
+	physicsDT   = 1.0 / physicsRate
+)
+
+var (
+	gravityPerSecond              = gravity * 60
+	jumpVelocityPerSecond float64 = jumpStrength * 60
+)
+
+// stepPhysics advances the bird's velocity and position by one fixed substep.
+func (g *Game) stepPhysics() {
+	g.birdVelocity += gravityPerSecond * physicsDT
+	g.birdY += g.birdVelocity * physicsDT
+}
+
+// jump applies an instantaneous upward velocity, independent of substepping.
+func (g *Game) jump() {
+	g.birdVelocity = jumpVelocityPerSecond
+}
+
+// advancePhysics accumulates one fixed Update-call timestep, derived from
+// ebiten's configured tick rate rather than wall-clock time, and steps the
+// bird's physics at a fixed physicsRate, leaving prevBirdY/birdY set so Draw
+// can interpolate the render position between substeps. A fixed dt keeps the
+// substep count per tick identical across runs and machines, which --seed
+// and --replay depend on to reproduce a run bit-for-bit.
+func (g *Game) advancePhysics() {
+	dt := 1.0 / float64(ebiten.TPS())
+
+	g.physicsAccum += dt
+	for g.physicsAccum >= physicsDT {
+		g.prevBirdY = g.birdY
+		g.stepPhysics()
+		g.physicsAccum -= physicsDT
+	}
+}
+
+// renderBirdY interpolates between the previous and current physics state
+// using the fraction of a substep left in the accumulator, for smooth
+// rendering independent of the physics rate.
+func (g *Game) renderBirdY() float64 {
+	alpha := g.physicsAccum / physicsDT
+	return g.prevBirdY + (g.birdY-g.prevBirdY)*alpha
+}
+
+// resetPhysicsClock resets the fixed-timestep accumulator, used whenever play
+// starts or resumes so a pause or loading delay isn't mistaken for elapsed
+// physics time.
+func (g *Game) resetPhysicsClock() {
+	g.physicsAccum = 0
+	g.prevBirdY = g.birdY
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+)
+
+// replayRecorder appends the tick index of every space press to a file, so a
+// run can be reproduced later by replaying it against the same --seed.
+type replayRecorder struct {
+	file *os.File
+	tick int
+}
+
+// newReplayRecorder creates (or truncates) the replay file at path.
+func newReplayRecorder(path string) (*replayRecorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &replayRecorder{file: f}, nil
+}
+
+// tick advances the recorder's tick counter by one.
+func (r *replayRecorder) tickDone() {
+	r.tick++
+}
+
+// recordSpace logs a space press at the current tick.
+func (r *replayRecorder) recordSpace() {
+	fmt.Fprintln(r.file, r.tick)
+}
+
+// Close flushes the replay file to disk.
+func (r *replayRecorder) Close() error {
+	return r.file.Close()
+}
+
+// replayPlayer plays back the space-press ticks recorded by a replayRecorder
+// instead of reading real keyboard input.
+type replayPlayer struct {
+	ticks []int
+	idx   int
+	tick  int
+}
+
+// loadReplayPlayer reads a replay file written by a replayRecorder.
+func loadReplayPlayer(path string) (*replayPlayer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var ticks []int
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var t int
+		if _, err := fmt.Sscanf(scanner.Text(), "%d", &t); err != nil {
+			return nil, err
+		}
+		ticks = append(ticks, t)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &replayPlayer{ticks: ticks}, nil
+}
+
+// tickDone advances the player's tick counter by one.
+func (p *replayPlayer) tickDone() {
+	p.tick++
+}
+
+// spacePressed reports whether a space press was recorded for the current tick.
+func (p *replayPlayer) spacePressed() bool {
+	if p.idx >= len(p.ticks) {
+		return false
+	}
+	if p.ticks[p.idx] != p.tick {
+		return false
+	}
+	p.idx++
+	return true
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ScoreRecord is the persisted high-score record stored under the user config dir.
+type ScoreRecord struct {
+	Best       int       `json:"best"`
+	Plays      int       `json:"plays"`
+	LastPlayed time.Time `json:"lastPlayed"`
+}
+
+// scoreFilePath returns the path to the persisted score file, creating its
+// parent directory if necessary.
+func scoreFilePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	dir = filepath.Join(dir, "go-flappy-bird")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "scores.json"), nil
+}
+
+// loadScoreData reads the persisted score file, resetting to zero if it is
+// missing or corrupt so a bad file never blocks the game from starting.
+func loadScoreData() ScoreRecord {
+	path, err := scoreFilePath()
+	if err != nil {
+		log.Print(err)
+		return ScoreRecord{}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ScoreRecord{}
+	}
+	var record ScoreRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return ScoreRecord{}
+	}
+	return record
+}
+
+// saveScoreData writes record to the persisted score file.
+func saveScoreData(record ScoreRecord) error {
+	path, err := scoreFilePath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// recordRun updates the persisted score record after a run ends and saves it
+// to disk, remembering whether this run set a new best for the game-over screen.
+func (g *Game) recordRun() {
+	g.record.Plays++
+	g.record.LastPlayed = time.Now()
+	g.newBest = g.score > g.record.Best
+	if g.newBest {
+		g.record.Best = g.score
+	}
+	if err := saveScoreData(g.record); err != nil {
+		log.Print(err)
+	}
+}
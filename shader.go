@@ -0,0 +1,51 @@
+package main
+
+import (
+	_ "embed"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+//go:embed assets/shaders/crt.kage
+var crtShaderSrc []byte
+
+// Tuning constants for the CRT post-processing pass.
+const (
+	crtScanlineIntensity = 0.3
+	crtCurvature         = 0.15
+)
+
+var crtShader = mustCompileShader(crtShaderSrc)
+
+// mustCompileShader compiles the embedded crt.kage source at package init
+// time. A compile error here means the shipped .kage file has a syntax
+// error, so there's no sensible fallback but to fail loudly and immediately.
+func mustCompileShader(src []byte) *ebiten.Shader {
+	shader, err := ebiten.NewShader(src)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return shader
+}
+
+// sceneImage returns the offscreen buffer the scene is rendered to before the
+// CRT shader is applied, creating it on first use.
+func (g *Game) sceneImage() *ebiten.Image {
+	if g.crtScene == nil {
+		g.crtScene = ebiten.NewImage(screenWidth, screenHeight)
+	}
+	return g.crtScene
+}
+
+// applyCRTShader renders the offscreen scene onto screen through the CRT shader.
+func (g *Game) applyCRTShader(screen *ebiten.Image) {
+	op := &ebiten.DrawRectShaderOptions{}
+	op.Images[0] = g.crtScene
+	op.Uniforms = map[string]interface{}{
+		"Time":              float32(g.crtTime),
+		"ScanlineIntensity": float32(crtScanlineIntensity),
+		"Curvature":         float32(crtCurvature),
+	}
+	screen.DrawRectShader(screenWidth, screenHeight, crtShader, op)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"image"
+	_ "image/png"
+	"log"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+//go:embed assets/images/bird.png
+var birdSpriteSheetData []byte
+
+//go:embed assets/images/ground.png
+var groundSpriteData []byte
+
+//go:embed assets/images/city.png
+var citySpriteData []byte
+
+// Number of animation frames in the bird spritesheet and the size of each frame.
+const (
+	birdFrameCount = 3
+	birdFrameSize  = birdSize
+
+	// birdAnimationTicks is how many Update ticks each wing-flap frame lasts.
+	birdAnimationTicks = 6
+
+	// minBirdAngle and maxBirdAngle clamp the bird's rotation, in radians.
+	minBirdAngle = -0.5
+	maxBirdAngle = 1.5
+
+	// groundScrollFraction and cityScrollFraction control the parallax speed
+	// of each background layer relative to pipeSpeed.
+	groundScrollFraction = 1.0
+	cityScrollFraction   = 0.3
+
+	groundHeight = 40
+)
+
+// decodeImage decodes one of the go:embed'd sprite PNGs above into an
+// *ebiten.Image. It's only ever called with those compile-time constants, so
+// a decode error means a corrupt asset shipped in the binary, not bad input.
+func decodeImage(data []byte) *ebiten.Image {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		log.Fatal(err)
+	}
+	return ebiten.NewImageFromImage(img)
+}
+
+var (
+	birdSpriteSheet = decodeImage(birdSpriteSheetData)
+	groundSprite    = decodeImage(groundSpriteData)
+	citySprite      = decodeImage(citySpriteData)
+)
+
+// birdFrame returns the sub-image for the given animation frame of the bird spritesheet.
+func birdFrame(frame int) *ebiten.Image {
+	x := frame * birdFrameSize
+	rect := image.Rect(x, 0, x+birdFrameSize, birdFrameSize)
+	return birdSpriteSheet.SubImage(rect).(*ebiten.Image)
+}
+
+// birdRotation maps bird velocity (in units/sec, see physics.go) to a rotation
+// angle: nose-up on jump, nose-down when falling.
+func birdRotation(velocity float64) float64 {
+	angle := (velocity / 60) * 0.08
+	return math.Max(minBirdAngle, math.Min(maxBirdAngle, angle))
+}
+
+// drawParallaxLayer tiles sprite horizontally across the screen, offset by scrollX,
+// wrapping with floor-mod arithmetic so the layer scrolls seamlessly forever.
+func drawParallaxLayer(screen *ebiten.Image, sprite *ebiten.Image, scrollX float64, y float64) {
+	tileWidth := sprite.Bounds().Dx()
+	offset := math.Mod(scrollX, float64(tileWidth))
+	if offset > 0 {
+		offset -= float64(tileWidth)
+	}
+	for x := offset; x < screenWidth; x += float64(tileWidth) {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(x, y)
+		screen.DrawImage(sprite, op)
+	}
+}